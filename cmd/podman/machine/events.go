@@ -0,0 +1,114 @@
+//go:build amd64 || arm64
+// +build amd64 arm64
+
+package machine
+
+import (
+	"bufio"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+
+	"github.com/containers/podman/v4/cmd/podman/registry"
+	"github.com/containers/podman/v4/pkg/util"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+var (
+	eventsDescription = `Listen on a machine event publishing socket and print decoded
+  events as they arrive, for debugging machine event producers. Unless
+  --socket is given, a socket is created in the runtime directory so that a
+  "podman machine" command started afterwards discovers and connects to it.`
+	eventsCommand = &cobra.Command{
+		Use:     "events [options]",
+		Short:   "Stream machine events",
+		Long:    eventsDescription,
+		RunE:    events,
+		Args:    cobra.NoArgs,
+		Example: `podman machine events --socket /run/user/1000/podman/machine_events.sock`,
+	}
+
+	eventsOptions struct {
+		Socket string
+		From   uint64
+	}
+)
+
+func init() {
+	registry.Commands = append(registry.Commands, registry.CliCommand{
+		Command: eventsCommand,
+		Parent:  machineCmd,
+	})
+
+	flags := eventsCommand.Flags()
+	flags.StringVar(&eventsOptions.Socket, "socket", "", "Path of the machine event publishing socket to connect to")
+	flags.Uint64Var(&eventsOptions.From, "from", 0, "Request a replay of retained events starting at this sequence number")
+}
+
+func events(cmd *cobra.Command, _ []string) error {
+	socket := eventsOptions.Socket
+	if socket == "" {
+		path, err := defaultEventsDebugSock()
+		if err != nil {
+			return err
+		}
+		socket = path
+	}
+
+	// machineEventClient.connect dials out to pre-existing socket files;
+	// this debug command plays the role of that external consumer, so it
+	// must be the listener a producing "podman machine" process discovers
+	// and dials into, not another dialer racing for the same endpoint.
+	_ = os.Remove(socket)
+	ln, err := net.Listen("unix", socket)
+	if err != nil {
+		return fmt.Errorf("listening on machine event socket %q: %w", socket, err)
+	}
+	defer ln.Close()
+	defer os.Remove(socket)
+
+	fmt.Fprintf(os.Stderr, "Listening for machine events on %s\n", socket)
+	conn, err := ln.Accept()
+	if err != nil {
+		return fmt.Errorf("accepting machine event connection on %q: %w", socket, err)
+	}
+	defer conn.Close()
+
+	if eventsOptions.From > 0 {
+		req, err := json.Marshal(machineEventReplayRequest{Cmd: "replay", From: eventsOptions.From})
+		if err != nil {
+			return err
+		}
+		if _, err := conn.Write(append(req, '\n')); err != nil {
+			return fmt.Errorf("requesting replay from %q: %w", socket, err)
+		}
+	}
+
+	scanner := bufio.NewScanner(conn)
+	for scanner.Scan() {
+		var me machineEvent
+		if err := json.Unmarshal(scanner.Bytes(), &me); err != nil {
+			logrus.Warnf("Ignoring malformed machine event: %v", err)
+			continue
+		}
+		fmt.Fprintf(os.Stdout, "%d\t%s\t%s\t%s\n", me.Sequence, me.SentAt.Format("2006-01-02T15:04:05Z07:00"), me.Status, me.Name)
+	}
+	return scanner.Err()
+}
+
+// defaultEventsDebugSock picks a path matching the `machine_events.*\.sock`
+// pattern resolveEventSock scans for, so that a "podman machine" command
+// started afterwards will discover and dial into this command's listener.
+func defaultEventsDebugSock() (string, error) {
+	xdg, err := util.GetRuntimeDir()
+	if err != nil {
+		return "", fmt.Errorf("getting runtime dir: %w", err)
+	}
+	dir := filepath.Join(xdg, "podman")
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, fmt.Sprintf("machine_events_debug_%d.sock", os.Getpid())), nil
+}
@@ -4,6 +4,7 @@
 package machine
 
 import (
+	"bufio"
 	"errors"
 	"net"
 	"os"
@@ -22,13 +23,25 @@ import (
 	"github.com/spf13/cobra"
 )
 
+const (
+	// machineEventRingSize bounds how many recently published events are
+	// retained for replay when a consumer reconnects.
+	machineEventRingSize = 64
+
+	// machineEventReconnectMinBackoff/MaxBackoff bound the exponential
+	// backoff used while a publishing socket is reopened.
+	machineEventReconnectMinBackoff = 500 * time.Millisecond
+	machineEventReconnectMaxBackoff = 30 * time.Second
+)
+
 var (
 	// Pull in configured json library
 	json = registry.JSONLibrary()
 
-	sockPaths     []string   // Paths to unix domain sockets for publishing
-	openEventSock sync.Once  // Singleton support for opening sockets as needed
-	sockets       []net.Conn // Opened sockets, if any
+	sockPaths []string // Paths to unix domain sockets for publishing
+
+	publisher     *machineEventPublisher // Singleton support for opening sockets as needed
+	openEventSock sync.Once
 
 	// Command: podman _machine_
 	machineCmd = &cobra.Command{
@@ -82,25 +95,11 @@ func getMachines(toComplete string) ([]string, cobra.ShellCompDirective) {
 func initMachineEvents(cmd *cobra.Command, _ []string) error {
 	logrus.Debugf("Called machine %s.PersistentPreRunE(%s)", cmd.Name(), strings.Join(os.Args, " "))
 
-	sockPaths, err := resolveEventSock()
+	paths, err := resolveEventSock()
 	if err != nil {
 		return err
 	}
-
-	// No sockets found, so no need to publish events...
-	if len(sockPaths) == 0 {
-		return nil
-	}
-
-	for _, path := range sockPaths {
-		conn, err := (&net.Dialer{}).DialContext(registry.Context(), "unix", path)
-		if err != nil {
-			logrus.Warnf("Failed to open event socket %q: %v", path, err)
-			continue
-		}
-		logrus.Debugf("Machine event socket %q found", path)
-		sockets = append(sockets, conn)
-	}
+	sockPaths = paths
 	return nil
 }
 
@@ -144,45 +143,234 @@ func resolveEventSock() ([]string, error) {
 	return sockPaths, nil
 }
 
-func newMachineEvent(status events.Status, event events.Event) {
-	openEventSock.Do(func() {
-		// No sockets where found, so no need to publish events...
-		if len(sockPaths) == 0 {
-			return
-		}
+// machineEvent is the JSON Lines wire format written to publishing sockets:
+// the underlying libpod event, plus a monotonic sequence number and a
+// sender-side timestamp so a reconnecting consumer can request a replay.
+type machineEvent struct {
+	events.Event
+	Sequence uint64    `json:"sequence"`
+	SentAt   time.Time `json:"sent_at"`
+}
 
-		for _, path := range sockPaths {
-			conn, err := (&net.Dialer{}).DialContext(registry.Context(), "unix", path)
-			if err != nil {
-				logrus.Warnf("Failed to open event socket %q: %v", path, err)
-				continue
-			}
-			logrus.Debugf("Machine event socket %q found", path)
-			sockets = append(sockets, conn)
-		}
-	})
+// machineEventReplayRequest is the request frame a consumer may send back
+// over the same socket to ask for events it missed while disconnected.
+type machineEventReplayRequest struct {
+	Cmd  string `json:"cmd"`
+	From uint64 `json:"from"`
+}
+
+// machineEventPublisher fans newly published machine events out to every
+// configured socket path, reconnecting lazily on write failure and keeping a
+// bounded ring of recent events so reconnecting consumers can catch up.
+type machineEventPublisher struct {
+	mu      sync.Mutex
+	seq     uint64
+	ring    []machineEvent
+	clients []*machineEventClient
+}
 
+func newMachineEventPublisher(paths []string) *machineEventPublisher {
+	p := &machineEventPublisher{}
+	for _, path := range paths {
+		c := &machineEventClient{path: path, publisher: p}
+		p.clients = append(p.clients, c)
+		c.connect()
+	}
+	return p
+}
+
+func (p *machineEventPublisher) publish(status events.Status, event events.Event) {
 	event.Status = status
 	event.Time = time.Now()
 	event.Type = events.Machine
 
-	payload, err := json.Marshal(event)
+	p.mu.Lock()
+	p.seq++
+	me := machineEvent{Event: event, Sequence: p.seq, SentAt: event.Time}
+	p.ring = append(p.ring, me)
+	if len(p.ring) > machineEventRingSize {
+		p.ring = p.ring[len(p.ring)-machineEventRingSize:]
+	}
+	p.mu.Unlock()
+
+	payload, err := encodeMachineEvent(me)
 	if err != nil {
 		logrus.Errorf("Unable to format machine event: %q", err)
 		return
 	}
 
-	for _, sock := range sockets {
-		if _, err := sock.Write(payload); err != nil {
-			logrus.Errorf("Unable to write machine event: %q", err)
+	for _, c := range p.clients {
+		c.write(payload)
+	}
+}
+
+// replay returns the JSON Lines payloads for every retained event with a
+// sequence number greater than or equal to from.
+func (p *machineEventPublisher) replay(from uint64) [][]byte {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	var payloads [][]byte
+	for _, me := range p.ring {
+		if me.Sequence < from {
+			continue
+		}
+		payload, err := encodeMachineEvent(me)
+		if err != nil {
+			continue
+		}
+		payloads = append(payloads, payload)
+	}
+	return payloads
+}
+
+func (p *machineEventPublisher) close() {
+	for _, c := range p.clients {
+		c.close()
+	}
+}
+
+func encodeMachineEvent(me machineEvent) ([]byte, error) {
+	payload, err := json.Marshal(me)
+	if err != nil {
+		return nil, err
+	}
+	// Newline-delimited JSON so consumers can parse events with a scanner.
+	return append(payload, '\n'), nil
+}
+
+// machineEventClient owns the connection to a single publishing socket. It
+// reopens the connection in the background on write failure, backing off
+// exponentially, and services replay requests sent back by the consumer.
+type machineEventClient struct {
+	path      string
+	publisher *machineEventPublisher
+
+	mu     sync.Mutex
+	conn   net.Conn
+	closed bool
+
+	// writeMu serializes writes to conn so a live event published from
+	// the main flow can't interleave on the wire with a batch of events
+	// replayed from readRequests' goroutine, which would corrupt the
+	// JSON Lines framing.
+	writeMu sync.Mutex
+}
+
+func (c *machineEventClient) connect() {
+	conn, err := (&net.Dialer{}).DialContext(registry.Context(), "unix", c.path)
+	if err != nil {
+		logrus.Warnf("Failed to open event socket %q: %v", c.path, err)
+		go c.reconnect()
+		return
+	}
+	logrus.Debugf("Machine event socket %q found", c.path)
+	c.setConn(conn)
+	go c.readRequests(conn)
+}
+
+func (c *machineEventClient) reconnect() {
+	backoff := machineEventReconnectMinBackoff
+	for {
+		time.Sleep(backoff)
+
+		c.mu.Lock()
+		closed := c.closed
+		c.mu.Unlock()
+		if closed {
+			return
+		}
+
+		conn, err := (&net.Dialer{}).DialContext(registry.Context(), "unix", c.path)
+		if err != nil {
+			logrus.Debugf("Failed to reconnect to machine event socket %q: %v", c.path, err)
+			if backoff *= 2; backoff > machineEventReconnectMaxBackoff {
+				backoff = machineEventReconnectMaxBackoff
+			}
+			continue
+		}
+
+		logrus.Debugf("Reconnected to machine event socket %q", c.path)
+		c.setConn(conn)
+		go c.readRequests(conn)
+		return
+	}
+}
+
+func (c *machineEventClient) setConn(conn net.Conn) {
+	c.mu.Lock()
+	c.conn = conn
+	c.mu.Unlock()
+}
+
+func (c *machineEventClient) write(payload []byte) {
+	c.mu.Lock()
+	conn := c.conn
+	c.mu.Unlock()
+	if conn == nil {
+		return
+	}
+
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+
+	if _, err := conn.Write(payload); err != nil {
+		logrus.Errorf("Unable to write machine event to %q: %v", c.path, err)
+		c.mu.Lock()
+		c.conn = nil
+		c.mu.Unlock()
+		_ = conn.Close()
+		go c.reconnect()
+	}
+}
+
+func (c *machineEventClient) readRequests(conn net.Conn) {
+	scanner := bufio.NewScanner(conn)
+	for scanner.Scan() {
+		var req machineEventReplayRequest
+		if err := json.Unmarshal(scanner.Bytes(), &req); err != nil {
+			logrus.Debugf("Ignoring malformed machine event request on %q: %v", c.path, err)
+			continue
 		}
+		if req.Cmd != "replay" {
+			continue
+		}
+		for _, payload := range c.publisher.replay(req.From) {
+			c.write(payload)
+		}
+	}
+}
+
+func (c *machineEventClient) close() {
+	c.mu.Lock()
+	c.closed = true
+	conn := c.conn
+	c.conn = nil
+	c.mu.Unlock()
+	if conn != nil {
+		_ = conn.Close()
+	}
+}
+
+func newMachineEvent(status events.Status, event events.Event) {
+	openEventSock.Do(func() {
+		// No sockets where found, so no need to publish events...
+		if len(sockPaths) == 0 {
+			return
+		}
+		publisher = newMachineEventPublisher(sockPaths)
+	})
+
+	if publisher == nil {
+		return
 	}
+	publisher.publish(status, event)
 }
 
 func closeMachineEvents(cmd *cobra.Command, _ []string) error {
 	logrus.Debugf("Called machine %s.PersistentPostRunE(%s)", cmd.Name(), strings.Join(os.Args, " "))
-	for _, sock := range sockets {
-		_ = sock.Close()
+	if publisher != nil {
+		publisher.close()
 	}
 	return nil
 }
@@ -0,0 +1,113 @@
+//go:build amd64 || arm64
+// +build amd64 arm64
+
+package machine
+
+import (
+	"bufio"
+	"fmt"
+	"net"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+)
+
+// TestMachineEventClientWriteSerialization writes events on the same client
+// from several goroutines concurrently and asserts the consumer sees whole,
+// unmangled JSON Lines records: a missing writeMu would let conn.Write calls
+// interleave mid-payload and corrupt the framing.
+func TestMachineEventClientWriteSerialization(t *testing.T) {
+	server, client := net.Pipe()
+	defer server.Close()
+	defer client.Close()
+
+	c := &machineEventClient{path: "test", conn: client}
+
+	const writers = 8
+	const linesPerWriter = 50
+
+	var wg sync.WaitGroup
+	for w := 0; w < writers; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for i := 0; i < linesPerWriter; i++ {
+				line := fmt.Sprintf(`{"writer":%d,"i":%d}`, w, i)
+				c.write([]byte(line + "\n"))
+			}
+		}(w)
+	}
+
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+
+	scanner := bufio.NewScanner(server)
+	seen := 0
+	for seen < writers*linesPerWriter {
+		if !scanner.Scan() {
+			t.Fatalf("scanner stopped early after %d lines: %v", seen, scanner.Err())
+		}
+		line := scanner.Text()
+		var writerID, i int
+		if _, err := fmt.Sscanf(line, `{"writer":%d,"i":%d}`, &writerID, &i); err != nil {
+			t.Fatalf("received corrupted/interleaved line %q: %v", line, err)
+		}
+		seen++
+	}
+
+	<-done
+}
+
+// TestMachineEventClientReconnect exercises the reconnect path: a client
+// dialed in to a listening consumer must reopen the connection in the
+// background, with exponential backoff, after the consumer goes away and
+// comes back.
+func TestMachineEventClientReconnect(t *testing.T) {
+	sockPath := filepath.Join(t.TempDir(), "machine_events_test.sock")
+
+	ln, err := net.Listen("unix", sockPath)
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	defer ln.Close()
+
+	accept := func() net.Conn {
+		conn, err := ln.Accept()
+		if err != nil {
+			t.Fatalf("accept: %v", err)
+		}
+		return conn
+	}
+
+	acceptedCh := make(chan net.Conn, 1)
+	go func() { acceptedCh <- accept() }()
+
+	c := &machineEventClient{path: sockPath}
+	c.connect()
+	defer c.close()
+
+	first := <-acceptedCh
+	if first == nil {
+		t.Fatal("expected first connection to be accepted")
+	}
+
+	// Simulate the consumer restarting: close its end so the next write
+	// fails and the client falls back to its reconnect loop.
+	go func() { acceptedCh <- accept() }()
+	_ = first.Close()
+
+	c.write([]byte(`{"sequence":1}` + "\n"))
+
+	select {
+	case second := <-acceptedCh:
+		if second == nil {
+			t.Fatal("expected reconnect to establish a second connection")
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for reconnect")
+	}
+}
@@ -0,0 +1,52 @@
+package manifest
+
+import (
+	"fmt"
+
+	"github.com/containers/podman/v4/cmd/podman/registry"
+	"github.com/containers/podman/v4/pkg/domain/entities"
+	"github.com/spf13/cobra"
+)
+
+var (
+	addOpts = entities.ManifestAddOptions{}
+
+	addCmd = &cobra.Command{
+		Use:   "add [options] LIST IMAGE [IMAGE...]",
+		Short: "Add images to a manifest list or image index",
+		Long:  "Adds an image to a manifest list or image index.",
+		RunE:  add,
+		Args:  cobra.MinimumNArgs(2),
+		Example: `podman manifest add mylist:v1.11 docker://fedora
+  podman manifest add --index-annotation org.opencontainers.image.source=https://example.com/repo mylist:v1.11 docker://fedora`,
+	}
+)
+
+func init() {
+	registry.Commands = append(registry.Commands, registry.CliCommand{
+		Command: addCmd,
+		Parent:  manifestCmd,
+	})
+
+	flags := addCmd.Flags()
+	flags.BoolVar(&addOpts.All, "all", false, "accepted, but not applied when adding")
+	flags.StringArrayVar(&addOpts.Annotation, "annotation", nil, "set an `annotation` on the image referenced by the added entry")
+	flags.StringVar(&addOpts.Arch, "arch", "", "override the `architecture` of the specified image")
+	flags.StringArrayVar(&addOpts.Features, "features", nil, "override the `features` of the specified image")
+	flags.StringToStringVar(&addOpts.IndexAnnotations, "index-annotation", nil, "set an `annotation=value` on the image index itself, not on the image it references")
+	flags.StringVar(&addOpts.OS, "os", "", "override the `OS` of the specified image")
+	flags.StringArrayVar(&addOpts.OSFeatures, "os-feature", nil, "override the OS `features` of the specified image")
+	flags.StringVar(&addOpts.OSVersion, "os-version", "", "override the OS `version` of the specified image")
+	flags.StringVar(&addOpts.Variant, "variant", "", "override the `variant` of the specified image")
+}
+
+func add(cmd *cobra.Command, args []string) error {
+	addOpts.Images = args[1:]
+
+	listID, err := registry.ImageEngine().ManifestAdd(registry.Context(), args[0], addOpts)
+	if err != nil {
+		return err
+	}
+	fmt.Println(listID)
+	return nil
+}
@@ -0,0 +1,43 @@
+package manifest
+
+import (
+	"github.com/containers/podman/v4/cmd/podman/registry"
+	"github.com/containers/podman/v4/pkg/domain/entities"
+	"github.com/spf13/cobra"
+)
+
+var (
+	annotateOpts = entities.ManifestAnnotateOptions{}
+
+	annotateCmd = &cobra.Command{
+		Use:   "annotate [options] LIST IMAGE",
+		Short: "Add or update information about an entry in a manifest list or image index",
+		Long:  "Adds or updates information about an image or artifact referenced by a manifest list or image index.",
+		RunE:  annotate,
+		Args:  cobra.ExactArgs(2),
+		Example: `podman manifest annotate --arch arm64 mylist:v1.11 sha256:entrydigest
+  podman manifest annotate --index-annotation org.opencontainers.image.source=https://example.com/repo mylist:v1.11 sha256:entrydigest`,
+	}
+)
+
+func init() {
+	registry.Commands = append(registry.Commands, registry.CliCommand{
+		Command: annotateCmd,
+		Parent:  manifestCmd,
+	})
+
+	flags := annotateCmd.Flags()
+	flags.StringArrayVar(&annotateOpts.Annotation, "annotation", nil, "set an `annotation` on the referenced image, not on the image index")
+	flags.StringVar(&annotateOpts.Arch, "arch", "", "override the `architecture` of the referenced image")
+	flags.StringArrayVar(&annotateOpts.Features, "features", nil, "override the `features` of the referenced image")
+	flags.StringToStringVar(&annotateOpts.IndexAnnotations, "index-annotation", nil, "set an `annotation=value` on the image index itself, not on the image it references")
+	flags.StringVar(&annotateOpts.OS, "os", "", "override the `OS` of the referenced image")
+	flags.StringArrayVar(&annotateOpts.OSFeatures, "os-feature", nil, "override the OS `features` of the referenced image")
+	flags.StringVar(&annotateOpts.OSVersion, "os-version", "", "override the OS `version` of the referenced image")
+	flags.StringVar(&annotateOpts.Variant, "variant", "", "override the `variant` of the referenced image")
+}
+
+func annotate(cmd *cobra.Command, args []string) error {
+	_, err := registry.ImageEngine().ManifestAnnotate(registry.Context(), args[0], args[1], annotateOpts)
+	return err
+}
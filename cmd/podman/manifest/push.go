@@ -0,0 +1,61 @@
+package manifest
+
+import (
+	"fmt"
+
+	"github.com/containers/podman/v4/cmd/podman/registry"
+	"github.com/containers/podman/v4/pkg/domain/entities"
+	"github.com/spf13/cobra"
+)
+
+var (
+	pushOpts = entities.ManifestPushOptions{}
+
+	pushCmd = &cobra.Command{
+		Use:   "push [options] LIST DESTINATION [DESTINATION...]",
+		Short: "Push a manifest list or image index to a registry",
+		Long:  "Pushes a manifest list or image index to a registry. Given more than one destination, all of them are pushed to in parallel, rolling back the ones that succeeded if any of them fails.",
+		RunE:  push,
+		Args:  cobra.MinimumNArgs(2),
+		Example: `podman manifest push mylist:v1.11 docker://registry.example.com/repo:v1.11
+  podman manifest push mylist:v1.11 docker://registry1.example.com/repo:v1.11 docker://registry2.example.com/repo:v1.11`,
+	}
+)
+
+func init() {
+	registry.Commands = append(registry.Commands, registry.CliCommand{
+		Command: pushCmd,
+		Parent:  manifestCmd,
+	})
+
+	flags := pushCmd.Flags()
+	flags.BoolVar(&pushOpts.All, "all", false, "accepted, but not applied when pushing")
+	flags.StringVar(&pushOpts.Authfile, "authfile", "", "path of the authentication file")
+	flags.StringVar(&pushOpts.CertDir, "cert-dir", "", "use certificates at the specified path to access the registry")
+	flags.StringVar(&pushOpts.Creds, "creds", "", "use `username:password` for accessing the registry")
+	flags.StringVar(&pushOpts.DigestFile, "digestfile", "", "accepted, but no digest file is written")
+	flags.StringVarP(&pushOpts.Format, "format", "f", "", "manifest list type (oci or v2s2); accepted, but not applied when pushing")
+	flags.BoolVarP(&pushOpts.Purge, "purge", "p", false, "remove the manifest list if push succeeds")
+	flags.BoolVarP(&pushOpts.Quiet, "quiet", "q", false, "don't output progress information when pushing")
+	flags.BoolVar(&pushOpts.RemoveSignatures, "remove-signatures", false, "don't copy signatures when pushing")
+	flags.StringVar(&pushOpts.SignBy, "sign-by", "", "sign the pushed images with the GPG key that matches the specified fingerprint")
+	flags.StringVar(&pushOpts.SignBySigstorePrivateKeyFile, "sign-by-sigstore-private-key", "", "sign the pushed images with a sigstore private `key`")
+	flags.StringVar(&pushOpts.SignBySigstorePassphraseFile, "sign-by-sigstore-passphrase-file", "", "read the passphrase for the sigstore private key from the specified `path`")
+	flags.StringVar(&pushOpts.SignaturePolicy, "signature-policy", "", "use a signature policy from the specified `path`")
+	flags.BoolVar(&pushOpts.TlsVerify, "tls-verify", true, "require HTTPS and verify certificates when contacting the registry")
+}
+
+func push(cmd *cobra.Command, args []string) error {
+	pushOpts.Destinations = args[1:]
+
+	digest, err := registry.ImageEngine().ManifestPush(registry.Context(), args[0], pushOpts)
+	if err != nil {
+		return err
+	}
+	if !pushOpts.Quiet {
+		for _, destination := range pushOpts.Destinations {
+			fmt.Printf("Pushed %s to %s\n", digest, destination)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,92 @@
+package libimage
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/containers/image/v5/docker/reference"
+	"github.com/containers/image/v5/manifest"
+	"github.com/containers/image/v5/manifests"
+	storageTransport "github.com/containers/image/v5/storage"
+	"github.com/containers/image/v5/transports/alltransports"
+	"github.com/containers/image/v5/types"
+	digest "github.com/opencontainers/go-digest"
+)
+
+// LookupManifestList looks up name as a manifest list or image index already
+// present in local storage.
+func (r *Runtime) LookupManifestList(name string) (*ManifestList, error) {
+	ref, err := storageTransport.Transport.ParseStoreReference(r.store, name)
+	if err != nil {
+		return nil, fmt.Errorf("parsing manifest list name %q: %w", name, err)
+	}
+	img, err := storageTransport.Transport.GetStoreImage(r.store, ref)
+	if err != nil {
+		return nil, fmt.Errorf("looking up manifest list %q: %w", name, err)
+	}
+	list, err := manifests.LoadFromImage(r.store, img.ID)
+	if err != nil {
+		return nil, fmt.Errorf("loading manifest list %q: %w", name, err)
+	}
+	return NewManifestList(list, ref), nil
+}
+
+// CreateManifestList creates a new, empty manifest list or image index and
+// stores it locally under name.
+func (r *Runtime) CreateManifestList(name string) (*ManifestList, error) {
+	normalized, err := reference.ParseNormalizedNamed(name)
+	if err != nil {
+		return nil, fmt.Errorf("normalizing manifest list name %q: %w", name, err)
+	}
+
+	list := manifests.Create()
+	listID, err := list.SaveToImage(r.store, "", []string{normalized.String()}, manifest.DockerV2ListMediaType)
+	if err != nil {
+		return nil, fmt.Errorf("creating manifest list %q: %w", name, err)
+	}
+
+	ref, err := storageTransport.Transport.ParseStoreReference(r.store, "@"+listID)
+	if err != nil {
+		return nil, fmt.Errorf("referencing new manifest list %q: %w", name, err)
+	}
+	return NewManifestList(list, ref), nil
+}
+
+// RemoveManifestList removes the manifest list or image index named name
+// from local storage, used by the Purge option of the ABI image engine's
+// ManifestPush to clean up after a successful push.
+func (r *Runtime) RemoveManifestList(name string) error {
+	ref, err := storageTransport.Transport.ParseStoreReference(r.store, name)
+	if err != nil {
+		return fmt.Errorf("parsing manifest list name %q: %w", name, err)
+	}
+	img, err := storageTransport.Transport.GetStoreImage(r.store, ref)
+	if err != nil {
+		return fmt.Errorf("looking up manifest list %q: %w", name, err)
+	}
+	if _, err := r.store.DeleteImage(img.ID, true); err != nil {
+		return fmt.Errorf("removing manifest list %q: %w", name, err)
+	}
+	return nil
+}
+
+// ResolveManifestInstance resolves name, a single-platform image reference
+// suitable for alltransports.ParseImageName, to the digest, size and MIME
+// type of its manifest, as required by (*ManifestList).Add.
+func (r *Runtime) ResolveManifestInstance(ctx context.Context, sys *types.SystemContext, name string) (digest.Digest, int64, string, error) {
+	srcRef, err := alltransports.ParseImageName(name)
+	if err != nil {
+		return "", 0, "", fmt.Errorf("parsing image name %q: %w", name, err)
+	}
+	src, err := srcRef.NewImageSource(ctx, sys)
+	if err != nil {
+		return "", 0, "", fmt.Errorf("opening image %q: %w", name, err)
+	}
+	defer src.Close()
+
+	rawManifest, manifestType, err := src.GetManifest(ctx, nil)
+	if err != nil {
+		return "", 0, "", fmt.Errorf("reading manifest of %q: %w", name, err)
+	}
+	return digest.FromBytes(rawManifest), int64(len(rawManifest)), manifestType, nil
+}
@@ -0,0 +1,208 @@
+package libimage
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/containers/image/v5/copy"
+	"github.com/containers/image/v5/signature"
+	"github.com/containers/image/v5/transports/alltransports"
+	"github.com/containers/image/v5/types"
+	"github.com/sirupsen/logrus"
+)
+
+// ManifestPushOptions are the libimage-level counterpart of
+// entities.ManifestPushOptions, consumed by (*ManifestList).Push.
+type ManifestPushOptions struct {
+	Destinations                 []string
+	Quiet                        bool
+	Authfile                     string
+	CertDir                      string
+	Creds                        string
+	TlsVerify                    bool
+	DigestFile                   string
+	Format                       string
+	RemoveSignatures             bool
+	SignBy                       string
+	SignBySigstorePrivateKeyFile string
+	SignBySigstorePassphraseFile string
+	SignaturePolicy              string
+}
+
+// PushProgress reports the outcome of pushing the manifest list to a single
+// destination, for per-destination progress reporting.
+type PushProgress struct {
+	Destination string
+	Err         error
+}
+
+type pushOneFunc func(ctx context.Context, ref types.ImageReference, destination string, options ManifestPushOptions) error
+
+type rollbackOneFunc func(ctx context.Context, destination string, options ManifestPushOptions) error
+
+// Push copies the manifest list to every destination in options.Destinations
+// in parallel. If some destinations succeed and others fail, the succeeded
+// destinations are rolled back so the push is all-or-nothing across
+// destinations rather than left half-applied. The returned slice carries one
+// PushProgress per destination, in the same order as options.Destinations,
+// regardless of whether the overall push succeeded.
+func (m *ManifestList) Push(ctx context.Context, options ManifestPushOptions) ([]PushProgress, error) {
+	if len(options.Destinations) == 0 {
+		return nil, fmt.Errorf("no destinations given to push the manifest list to")
+	}
+
+	results := make([]PushProgress, len(options.Destinations))
+	var wg sync.WaitGroup
+	for i, destination := range options.Destinations {
+		wg.Add(1)
+		go func(i int, destination string) {
+			defer wg.Done()
+			err := m.pushOne(ctx, m.ref, destination, options)
+			results[i] = PushProgress{Destination: destination, Err: err}
+			if !options.Quiet {
+				if err != nil {
+					logrus.Errorf("Pushing manifest list to %q: %v", destination, err)
+				} else {
+					logrus.Debugf("Pushed manifest list to %q", destination)
+				}
+			}
+		}(i, destination)
+	}
+	wg.Wait()
+
+	var failed, succeeded []string
+	var firstErr error
+	for _, result := range results {
+		if result.Err != nil {
+			failed = append(failed, result.Destination)
+			if firstErr == nil {
+				firstErr = result.Err
+			}
+			continue
+		}
+		succeeded = append(succeeded, result.Destination)
+	}
+
+	switch {
+	case len(failed) == 0:
+		return results, nil
+	case len(succeeded) == 0:
+		return results, fmt.Errorf("pushing manifest list failed for all %d destination(s): %w", len(failed), firstErr)
+	default:
+		for _, destination := range succeeded {
+			if err := m.rollbackOne(ctx, destination, options); err != nil {
+				logrus.Errorf("Rolling back manifest list push to %q after a partial failure: %v", destination, err)
+			}
+		}
+		return results, fmt.Errorf("pushing manifest list failed for %d of %d destination(s), rolled back the %d that succeeded: %w",
+			len(failed), len(options.Destinations), len(succeeded), firstErr)
+	}
+}
+
+// pushManifestListInstance copies the manifest list referenced by ref to a
+// single destination, optionally signing it with a GPG key (SignBy) and/or a
+// cosign-compatible sigstore key (SignBySigstorePrivateKeyFile).
+func pushManifestListInstance(ctx context.Context, ref types.ImageReference, destination string, options ManifestPushOptions) error {
+	destRef, err := alltransports.ParseImageName(destination)
+	if err != nil {
+		return fmt.Errorf("parsing push destination %q: %w", destination, err)
+	}
+
+	policyContext, err := buildPushPolicyContext(options)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = policyContext.Destroy() }()
+
+	sys, err := buildSystemContext(options)
+	if err != nil {
+		return err
+	}
+
+	copyOptions := &copy.Options{
+		SourceCtx:        sys,
+		DestinationCtx:   sys,
+		RemoveSignatures: options.RemoveSignatures,
+		SignBy:           options.SignBy,
+	}
+	if options.SignBySigstorePrivateKeyFile != "" {
+		passphrase, err := readSigstorePassphrase(options.SignBySigstorePassphraseFile)
+		if err != nil {
+			return err
+		}
+		copyOptions.SignBySigstorePrivateKeyFile = options.SignBySigstorePrivateKeyFile
+		copyOptions.SignSigstorePrivateKeyPassphrase = passphrase
+	}
+
+	_, err = copy.Image(ctx, policyContext, destRef, ref, copyOptions)
+	return err
+}
+
+// buildSystemContext turns the authentication- and TLS-related fields of
+// options into the *types.SystemContext that copy.Image and DeleteImage need
+// in order to actually authenticate against destination, instead of silently
+// falling back to anonymous, unverified access.
+func buildSystemContext(options ManifestPushOptions) (*types.SystemContext, error) {
+	sys := &types.SystemContext{
+		AuthFilePath:                options.Authfile,
+		DockerCertPath:              options.CertDir,
+		DockerInsecureSkipTLSVerify: types.NewOptionalBool(!options.TlsVerify),
+	}
+	if options.Creds != "" {
+		username, password, ok := strings.Cut(options.Creds, ":")
+		if !ok {
+			return nil, fmt.Errorf("invalid --creds value %q: expected format `username:password`", options.Creds)
+		}
+		sys.DockerAuthConfig = &types.DockerAuthConfig{Username: username, Password: password}
+	}
+	return sys, nil
+}
+
+// readSigstorePassphrase reads the sigstore private key passphrase out of
+// path, trimming the trailing newline a human-edited file is likely to have.
+// copy.Options wants the passphrase itself, not the path to it.
+func readSigstorePassphrase(path string) ([]byte, error) {
+	if path == "" {
+		return nil, nil
+	}
+	passphrase, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading sigstore passphrase from %q: %w", path, err)
+	}
+	return bytes.TrimRight(passphrase, "\n"), nil
+}
+
+func buildPushPolicyContext(options ManifestPushOptions) (*signature.PolicyContext, error) {
+	var policy *signature.Policy
+	var err error
+	if options.SignaturePolicy != "" {
+		policy, err = signature.NewPolicyFromFile(options.SignaturePolicy)
+	} else {
+		policy, err = signature.DefaultPolicy(nil)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("obtaining signature policy: %w", err)
+	}
+	return signature.NewPolicyContext(policy)
+}
+
+// rollbackManifestListPush best-effort deletes a manifest list that was
+// successfully pushed to destination, used to undo a partially-succeeded
+// multi-destination push. It reuses the same auth/TLS options as the push
+// itself, since destination may require authentication to delete from just
+// as it did to push to.
+func rollbackManifestListPush(ctx context.Context, destination string, options ManifestPushOptions) error {
+	destRef, err := alltransports.ParseImageName(destination)
+	if err != nil {
+		return fmt.Errorf("parsing rollback destination %q: %w", destination, err)
+	}
+	sys, err := buildSystemContext(options)
+	if err != nil {
+		return err
+	}
+	return destRef.DeleteImage(ctx, sys)
+}
@@ -0,0 +1,164 @@
+package libimage
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/containers/image/v5/types"
+)
+
+func newTestManifestList() *ManifestList {
+	return &ManifestList{}
+}
+
+func TestManifestListPushAllSucceed(t *testing.T) {
+	m := newTestManifestList()
+
+	var mu sync.Mutex
+	var pushed []string
+	m.pushOne = func(_ context.Context, _ types.ImageReference, destination string, _ ManifestPushOptions) error {
+		mu.Lock()
+		pushed = append(pushed, destination)
+		mu.Unlock()
+		return nil
+	}
+	rollbackCalled := false
+	m.rollbackOne = func(_ context.Context, _ string, _ ManifestPushOptions) error {
+		rollbackCalled = true
+		return nil
+	}
+
+	options := ManifestPushOptions{Destinations: []string{"registry.example.com/a", "registry.example.com/b"}}
+	results, err := m.Push(context.Background(), options)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(results) != 2 {
+		t.Fatalf("expected 2 results, got %d", len(results))
+	}
+	for i, r := range results {
+		if r.Destination != options.Destinations[i] {
+			t.Fatalf("result %d: expected destination %q, got %q", i, options.Destinations[i], r.Destination)
+		}
+		if r.Err != nil {
+			t.Fatalf("result %d: unexpected error: %v", i, r.Err)
+		}
+	}
+	if rollbackCalled {
+		t.Fatal("rollback should not be called when every destination succeeds")
+	}
+}
+
+func TestManifestListPushPartialFailureRollsBack(t *testing.T) {
+	m := newTestManifestList()
+
+	boom := errors.New("boom")
+	m.pushOne = func(_ context.Context, _ types.ImageReference, destination string, _ ManifestPushOptions) error {
+		if destination == "registry.example.com/bad" {
+			return boom
+		}
+		return nil
+	}
+
+	var mu sync.Mutex
+	var rolledBack []string
+	m.rollbackOne = func(_ context.Context, destination string, _ ManifestPushOptions) error {
+		mu.Lock()
+		rolledBack = append(rolledBack, destination)
+		mu.Unlock()
+		return nil
+	}
+
+	options := ManifestPushOptions{Destinations: []string{"registry.example.com/good", "registry.example.com/bad"}}
+	results, err := m.Push(context.Background(), options)
+	if err == nil {
+		t.Fatal("expected an error for a partially-failed push")
+	}
+	if len(results) != 2 {
+		t.Fatalf("expected 2 results, got %d", len(results))
+	}
+	if len(rolledBack) != 1 || rolledBack[0] != "registry.example.com/good" {
+		t.Fatalf("expected only the succeeded destination to be rolled back, got %v", rolledBack)
+	}
+}
+
+func TestManifestListPushAllFailNoRollback(t *testing.T) {
+	m := newTestManifestList()
+
+	boom := errors.New("boom")
+	m.pushOne = func(_ context.Context, _ types.ImageReference, _ string, _ ManifestPushOptions) error {
+		return boom
+	}
+	rollbackCalled := false
+	m.rollbackOne = func(_ context.Context, _ string, _ ManifestPushOptions) error {
+		rollbackCalled = true
+		return nil
+	}
+
+	options := ManifestPushOptions{Destinations: []string{"registry.example.com/a", "registry.example.com/b"}}
+	_, err := m.Push(context.Background(), options)
+	if err == nil {
+		t.Fatal("expected an error when every destination fails")
+	}
+	if rollbackCalled {
+		t.Fatal("rollback should not run when nothing succeeded")
+	}
+}
+
+func TestManifestListPushNoDestinations(t *testing.T) {
+	m := newTestManifestList()
+	if _, err := m.Push(context.Background(), ManifestPushOptions{}); err == nil {
+		t.Fatal("expected an error when no destinations are given")
+	}
+}
+
+func TestReadSigstorePassphrase(t *testing.T) {
+	if passphrase, err := readSigstorePassphrase(""); err != nil || passphrase != nil {
+		t.Fatalf("expected a nil passphrase and no error for an empty path, got %q, %v", passphrase, err)
+	}
+
+	path := filepath.Join(t.TempDir(), "passphrase")
+	if err := os.WriteFile(path, []byte("hunter2\n"), 0o600); err != nil {
+		t.Fatalf("writing test passphrase file: %v", err)
+	}
+	passphrase, err := readSigstorePassphrase(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if string(passphrase) != "hunter2" {
+		t.Fatalf("expected the trailing newline to be trimmed, got %q", passphrase)
+	}
+}
+
+func TestBuildSystemContext(t *testing.T) {
+	sys, err := buildSystemContext(ManifestPushOptions{
+		Authfile:   "/tmp/auth.json",
+		CertDir:    "/tmp/certs",
+		Creds:      "alice:s3cret",
+		TlsVerify:  false,
+		DigestFile: "/tmp/digest",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if sys.AuthFilePath != "/tmp/auth.json" {
+		t.Fatalf("expected AuthFilePath to be set, got %q", sys.AuthFilePath)
+	}
+	if sys.DockerCertPath != "/tmp/certs" {
+		t.Fatalf("expected DockerCertPath to be set, got %q", sys.DockerCertPath)
+	}
+	if sys.DockerInsecureSkipTLSVerify != types.OptionalBoolTrue {
+		t.Fatalf("expected DockerInsecureSkipTLSVerify to be true when TlsVerify is false")
+	}
+	if sys.DockerAuthConfig == nil || sys.DockerAuthConfig.Username != "alice" || sys.DockerAuthConfig.Password != "s3cret" {
+		t.Fatalf("expected DockerAuthConfig to be parsed from Creds, got %+v", sys.DockerAuthConfig)
+	}
+
+	if _, err := buildSystemContext(ManifestPushOptions{Creds: "no-colon-and-no-password"}); err == nil {
+		t.Fatal("expected an error for a malformed --creds value")
+	}
+}
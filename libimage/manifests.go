@@ -0,0 +1,160 @@
+package libimage
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/containers/image/v5/manifests"
+	"github.com/containers/image/v5/types"
+	digest "github.com/opencontainers/go-digest"
+)
+
+// annotationSetter is the subset of manifests.List that
+// applyManifestAnnotations needs; a minimal interface so it can be exercised
+// against a fake in tests instead of the full manifests.List surface.
+type annotationSetter interface {
+	SetAnnotations(instanceDigest *digest.Digest, annotations map[string]string) error
+}
+
+// applyManifestAnnotations writes per-descriptor annotations onto
+// instanceDigest and index-level annotations onto the manifest list itself.
+// The OCI image index spec distinguishes the two: annotations passed with a
+// nil instanceDigest describe the index as a whole, while annotations passed
+// with a non-nil instanceDigest describe only the one manifest it
+// references. ManifestList.Add and ManifestList.AnnotateInstance must keep that
+// distinction straight, which is the entire point of having both an
+// Annotation and an IndexAnnotations field on the options structs.
+func applyManifestAnnotations(list annotationSetter, instanceDigest *digest.Digest, annotation []string, indexAnnotations map[string]string) error {
+	if len(annotation) > 0 {
+		descriptorAnnotations, err := splitAnnotations(annotation)
+		if err != nil {
+			return err
+		}
+		if err := list.SetAnnotations(instanceDigest, descriptorAnnotations); err != nil {
+			return fmt.Errorf("setting descriptor annotations: %w", err)
+		}
+	}
+	if len(indexAnnotations) > 0 {
+		if err := list.SetAnnotations(nil, indexAnnotations); err != nil {
+			return fmt.Errorf("setting index annotations: %w", err)
+		}
+	}
+	return nil
+}
+
+// splitAnnotations turns a list of "key=value" strings, as accepted by the
+// --annotation CLI flag, into a map.
+func splitAnnotations(pairs []string) (map[string]string, error) {
+	annotations := make(map[string]string, len(pairs))
+	for _, pair := range pairs {
+		key, value, ok := strings.Cut(pair, "=")
+		if !ok {
+			return nil, fmt.Errorf("no value given for annotation %q", pair)
+		}
+		annotations[key] = value
+	}
+	return annotations, nil
+}
+
+// ManifestAddOptions are the libimage-level counterpart of
+// entities.ManifestAddOptions, consumed by (*ManifestList).Add.
+type ManifestAddOptions struct {
+	All              bool
+	Annotation       []string
+	Arch             string
+	Features         []string
+	IndexAnnotations map[string]string
+	OS               string
+	OSFeatures       []string
+	OSVersion        string
+	Variant          string
+}
+
+// ManifestAnnotateOptions are the libimage-level counterpart of
+// entities.ManifestAnnotateOptions, consumed by (*ManifestList).AnnotateInstance.
+type ManifestAnnotateOptions struct {
+	Annotation       []string
+	Arch             string
+	Features         []string
+	IndexAnnotations map[string]string
+	OS               string
+	OSFeatures       []string
+	OSVersion        string
+	Variant          string
+}
+
+// ManifestList is a thin wrapper around a containers/image manifest list
+// being built up by "podman manifest add/annotate/push".
+type ManifestList struct {
+	list manifests.List
+	// ref references the list as it is stored locally; Push uses it as
+	// the source of each per-destination copy.
+	ref types.ImageReference
+
+	// pushOne and rollbackOne default to pushManifestListInstance and
+	// rollbackManifestListPush; tests override them to exercise Push's
+	// orchestration without talking to a real registry.
+	pushOne     pushOneFunc
+	rollbackOne rollbackOneFunc
+}
+
+// NewManifestList wraps list, whose local storage reference is ref, for
+// further manipulation and pushing.
+func NewManifestList(list manifests.List, ref types.ImageReference) *ManifestList {
+	return &ManifestList{
+		list:        list,
+		ref:         ref,
+		pushOne:     pushManifestListInstance,
+		rollbackOne: rollbackManifestListPush,
+	}
+}
+
+// ID returns the identifier under which the manifest list is stored locally.
+func (m *ManifestList) ID() string {
+	return m.ref.StringWithinTransport()
+}
+
+// Add records manifestDigest as an instance of the list, applying the given
+// per-platform overrides and annotations, plus any index-level annotations.
+func (m *ManifestList) Add(manifestDigest digest.Digest, manifestSize int64, manifestType string, options ManifestAddOptions) error {
+	if err := m.list.AddInstance(manifestDigest, manifestSize, manifestType, options.OS, options.Arch, options.OSVersion, options.OSFeatures, options.Variant, options.Features, options.Annotation); err != nil {
+		return fmt.Errorf("adding instance %s: %w", manifestDigest, err)
+	}
+	return applyManifestAnnotations(m.list, &manifestDigest, nil, options.IndexAnnotations)
+}
+
+// AnnotateInstance updates the per-platform fields and annotations of the
+// instance identified by instanceDigest, plus any index-level annotations.
+func (m *ManifestList) AnnotateInstance(instanceDigest digest.Digest, options ManifestAnnotateOptions) error {
+	if options.OS != "" {
+		if err := m.list.SetOS(instanceDigest, options.OS); err != nil {
+			return fmt.Errorf("setting os of %s: %w", instanceDigest, err)
+		}
+	}
+	if options.Arch != "" {
+		if err := m.list.SetArchitecture(instanceDigest, options.Arch); err != nil {
+			return fmt.Errorf("setting architecture of %s: %w", instanceDigest, err)
+		}
+	}
+	if options.OSVersion != "" {
+		if err := m.list.SetOSVersion(instanceDigest, options.OSVersion); err != nil {
+			return fmt.Errorf("setting os version of %s: %w", instanceDigest, err)
+		}
+	}
+	if len(options.OSFeatures) > 0 {
+		if err := m.list.SetOSFeatures(instanceDigest, options.OSFeatures); err != nil {
+			return fmt.Errorf("setting os features of %s: %w", instanceDigest, err)
+		}
+	}
+	if options.Variant != "" {
+		if err := m.list.SetVariant(instanceDigest, options.Variant); err != nil {
+			return fmt.Errorf("setting variant of %s: %w", instanceDigest, err)
+		}
+	}
+	if len(options.Features) > 0 {
+		if err := m.list.SetFeatures(instanceDigest, options.Features); err != nil {
+			return fmt.Errorf("setting features of %s: %w", instanceDigest, err)
+		}
+	}
+	return applyManifestAnnotations(m.list, &instanceDigest, options.Annotation, options.IndexAnnotations)
+}
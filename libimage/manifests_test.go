@@ -0,0 +1,113 @@
+package libimage
+
+import (
+	"reflect"
+	"testing"
+
+	digest "github.com/opencontainers/go-digest"
+)
+
+// fakeAnnotationSetter is a minimal annotationSetter fake recording each call,
+// so applyManifestAnnotations' index-vs-descriptor routing can be tested
+// without implementing the much larger manifests.List interface.
+type fakeAnnotationSetter struct {
+	calls []struct {
+		instanceDigest *digest.Digest
+		annotations    map[string]string
+	}
+}
+
+func (f *fakeAnnotationSetter) SetAnnotations(instanceDigest *digest.Digest, annotations map[string]string) error {
+	f.calls = append(f.calls, struct {
+		instanceDigest *digest.Digest
+		annotations    map[string]string
+	}{instanceDigest, annotations})
+	return nil
+}
+
+func TestApplyManifestAnnotationsRoutesIndexAndDescriptor(t *testing.T) {
+	instance := digest.FromString("instance")
+	fake := &fakeAnnotationSetter{}
+
+	err := applyManifestAnnotations(fake, &instance, []string{"a=1"}, map[string]string{"b": "2"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(fake.calls) != 2 {
+		t.Fatalf("expected 2 calls, got %d", len(fake.calls))
+	}
+
+	descriptorCall := fake.calls[0]
+	if descriptorCall.instanceDigest == nil || *descriptorCall.instanceDigest != instance {
+		t.Fatalf("expected descriptor annotations to be set with instance digest %v, got %v", instance, descriptorCall.instanceDigest)
+	}
+	if !reflect.DeepEqual(descriptorCall.annotations, map[string]string{"a": "1"}) {
+		t.Fatalf("unexpected descriptor annotations: %v", descriptorCall.annotations)
+	}
+
+	indexCall := fake.calls[1]
+	if indexCall.instanceDigest != nil {
+		t.Fatalf("expected index annotations to be set with a nil instance digest, got %v", indexCall.instanceDigest)
+	}
+	if !reflect.DeepEqual(indexCall.annotations, map[string]string{"b": "2"}) {
+		t.Fatalf("unexpected index annotations: %v", indexCall.annotations)
+	}
+}
+
+func TestApplyManifestAnnotationsNoop(t *testing.T) {
+	fake := &fakeAnnotationSetter{}
+	if err := applyManifestAnnotations(fake, nil, nil, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(fake.calls) != 0 {
+		t.Fatalf("expected no calls when there is nothing to annotate, got %d", len(fake.calls))
+	}
+}
+
+func TestSplitAnnotations(t *testing.T) {
+	tests := []struct {
+		name    string
+		pairs   []string
+		want    map[string]string
+		wantErr bool
+	}{
+		{
+			name:  "single pair",
+			pairs: []string{"com.example.key=value"},
+			want:  map[string]string{"com.example.key": "value"},
+		},
+		{
+			name:  "multiple pairs",
+			pairs: []string{"a=1", "b=2"},
+			want:  map[string]string{"a": "1", "b": "2"},
+		},
+		{
+			name:  "value containing an equals sign",
+			pairs: []string{"a=b=c"},
+			want:  map[string]string{"a": "b=c"},
+		},
+		{
+			name:    "missing value",
+			pairs:   []string{"novalue"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := splitAnnotations(tt.pairs)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("expected an error, got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
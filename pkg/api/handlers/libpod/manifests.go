@@ -0,0 +1,117 @@
+package libpod
+
+import (
+	"net/http"
+
+	"github.com/containers/podman/v4/pkg/api/handlers/utils"
+	"github.com/containers/podman/v4/pkg/domain/entities"
+	"github.com/containers/podman/v4/pkg/domain/infra/abi"
+	"github.com/gorilla/schema"
+)
+
+// ManifestAddHandler decodes a ManifestAddOptions query, including the
+// OSFeatures and IndexAnnotations fields, and adds the named image(s) to the
+// manifest list or image index.
+func ManifestAddHandler(w http.ResponseWriter, r *http.Request) {
+	options := entities.ManifestAddOptions{}
+	decoder := r.Context().Value(utils.DecoderKey).(*schema.Decoder)
+	if err := decoder.Decode(&options, r.URL.Query()); err != nil {
+		utils.Error(w, http.StatusBadRequest, err)
+		return
+	}
+
+	name := utils.GetVar(r, "name")
+	imageEngine := abi.ImageEngine{Libpod: utils.GetRuntime(r.Context())}
+	listID, err := imageEngine.ManifestAdd(r.Context(), name, options)
+	if err != nil {
+		utils.Error(w, http.StatusInternalServerError, err)
+		return
+	}
+	utils.WriteResponse(w, http.StatusOK, entities.IDResponse{ID: listID})
+}
+
+// ManifestAnnotateHandler decodes a ManifestAnnotateOptions query, including
+// the OSFeatures and IndexAnnotations fields, and applies it to the named
+// instance of a manifest list or image index.
+func ManifestAnnotateHandler(w http.ResponseWriter, r *http.Request) {
+	options := entities.ManifestAnnotateOptions{}
+	decoder := r.Context().Value(utils.DecoderKey).(*schema.Decoder)
+	if err := decoder.Decode(&options, r.URL.Query()); err != nil {
+		utils.Error(w, http.StatusBadRequest, err)
+		return
+	}
+
+	name := utils.GetVar(r, "name")
+	instanceDigest := utils.GetVar(r, "digest")
+	imageEngine := abi.ImageEngine{Libpod: utils.GetRuntime(r.Context())}
+	listID, err := imageEngine.ManifestAnnotate(r.Context(), name, instanceDigest, options)
+	if err != nil {
+		utils.Error(w, http.StatusInternalServerError, err)
+		return
+	}
+	utils.WriteResponse(w, http.StatusOK, entities.IDResponse{ID: listID})
+}
+
+// manifestPushQuery is decoded separately from entities.ManifestPushOptions:
+// ManifestPushOptions carries no schema tags because the CLI builds it
+// directly, so the wire format is defined here instead, with "destination"
+// repeatable for the multi-destination, atomic-fan-out push.
+type manifestPushQuery struct {
+	All                          bool     `schema:"all"`
+	Destination                  []string `schema:"destination"`
+	Authfile                     string   `schema:"authfile"`
+	CertDir                      string   `schema:"certDir"`
+	Creds                        string   `schema:"creds"`
+	DigestFile                   string   `schema:"digestfile"`
+	Format                       string   `schema:"format"`
+	Purge                        bool     `schema:"purge"`
+	Quiet                        bool     `schema:"quiet"`
+	RemoveSignatures             bool     `schema:"removeSignatures"`
+	SignBy                       string   `schema:"signBy"`
+	SignBySigstorePrivateKeyFile string   `schema:"signBySigstorePrivateKeyFile"`
+	SignBySigstorePassphraseFile string   `schema:"signBySigstorePassphraseFile"`
+	SignaturePolicy              string   `schema:"signaturePolicy"`
+	TlsVerify                    bool     `schema:"tlsVerify"`
+}
+
+// ManifestPushHandler decodes a manifestPushQuery, including the
+// destination(s) to fan the push out to and the sigstore signing fields,
+// and pushes the named manifest list or image index.
+func ManifestPushHandler(w http.ResponseWriter, r *http.Request) {
+	query := manifestPushQuery{TlsVerify: true}
+	decoder := r.Context().Value(utils.DecoderKey).(*schema.Decoder)
+	if err := decoder.Decode(&query, r.URL.Query()); err != nil {
+		utils.Error(w, http.StatusBadRequest, err)
+		return
+	}
+
+	name := utils.GetVar(r, "name")
+	destination := utils.GetVar(r, "destination")
+	destinations := append([]string{destination}, query.Destination...)
+
+	options := entities.ManifestPushOptions{
+		All:                          query.All,
+		Authfile:                     query.Authfile,
+		CertDir:                      query.CertDir,
+		Creds:                        query.Creds,
+		DigestFile:                   query.DigestFile,
+		Format:                       query.Format,
+		Purge:                        query.Purge,
+		Quiet:                        query.Quiet,
+		RemoveSignatures:             query.RemoveSignatures,
+		SignBy:                       query.SignBy,
+		SignBySigstorePrivateKeyFile: query.SignBySigstorePrivateKeyFile,
+		SignBySigstorePassphraseFile: query.SignBySigstorePassphraseFile,
+		SignaturePolicy:              query.SignaturePolicy,
+		TlsVerify:                    query.TlsVerify,
+		Destinations:                 destinations,
+	}
+
+	imageEngine := abi.ImageEngine{Libpod: utils.GetRuntime(r.Context())}
+	digest, err := imageEngine.ManifestPush(r.Context(), name, options)
+	if err != nil {
+		utils.Error(w, http.StatusInternalServerError, err)
+		return
+	}
+	utils.WriteResponse(w, http.StatusOK, entities.IDResponse{ID: digest})
+}
@@ -5,27 +5,40 @@ type ManifestCreateOptions struct {
 }
 
 type ManifestAddOptions struct {
-	All        bool     `json:"all" schema:"all"`
-	Annotation []string `json:"annotation" schema:"annotation"`
-	Arch       string   `json:"arch" schema:"arch"`
-	Features   []string `json:"features" schema:"features"`
-	Images     []string `json:"images" schema:"images"`
-	OS         string   `json:"os" schema:"os"`
-	OSVersion  string   `json:"os_version" schema:"os_version"`
-	Variant    string   `json:"variant" schema:"variant"`
+	All              bool              `json:"all" schema:"all"`
+	Annotation       []string          `json:"annotation" schema:"annotation"`
+	Arch             string            `json:"arch" schema:"arch"`
+	Features         []string          `json:"features" schema:"features"`
+	Images           []string          `json:"images" schema:"images"`
+	IndexAnnotations map[string]string `json:"index_annotations" schema:"index_annotations"`
+	OS               string            `json:"os" schema:"os"`
+	OSFeatures       []string          `json:"os_features" schema:"os_features"`
+	OSVersion        string            `json:"os_version" schema:"os_version"`
+	Variant          string            `json:"variant" schema:"variant"`
 }
 
 type ManifestAnnotateOptions struct {
-	Annotation []string `json:"annotation"`
-	Arch       string   `json:"arch" schema:"arch"`
-	Features   []string `json:"features" schema:"features"`
-	OS         string   `json:"os" schema:"os"`
-	OSFeatures []string `json:"os_features" schema:"os_features"`
-	OSVersion  string   `json:"os_version" schema:"os_version"`
-	Variant    string   `json:"variant" schema:"variant"`
+	Annotation       []string          `json:"annotation"`
+	Arch             string            `json:"arch" schema:"arch"`
+	Features         []string          `json:"features" schema:"features"`
+	IndexAnnotations map[string]string `json:"index_annotations" schema:"index_annotations"`
+	OS               string            `json:"os" schema:"os"`
+	OSFeatures       []string          `json:"os_features" schema:"os_features"`
+	OSVersion        string            `json:"os_version" schema:"os_version"`
+	Variant          string            `json:"variant" schema:"variant"`
 }
 
 type ManifestPushOptions struct {
 	Purge, Quiet, All, TlsVerify, RemoveSignatures       bool
 	Authfile, CertDir, Creds, DigestFile, Format, SignBy string
+	// Destinations holds every registry destination the manifest list is
+	// pushed to, so a single invocation can fan out to multiple
+	// registries atomically.
+	Destinations []string
+	// SignBySigstorePrivateKeyFile and SignBySigstorePassphraseFile sign
+	// the pushed manifest list with a cosign-compatible sigstore key,
+	// in addition to or instead of the GPG key named by SignBy.
+	SignBySigstorePrivateKeyFile string
+	SignBySigstorePassphraseFile string
+	SignaturePolicy              string
 }
@@ -0,0 +1,11 @@
+package abi
+
+import (
+	"github.com/containers/podman/v4/libpod"
+)
+
+// ImageEngine is the ABI (non-tunnel) implementation of entities.ImageEngine,
+// backed directly by a local libpod Runtime.
+type ImageEngine struct {
+	Libpod *libpod.Runtime
+}
@@ -0,0 +1,116 @@
+package abi
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/containers/podman/v4/libimage"
+	"github.com/containers/podman/v4/pkg/domain/entities"
+	digest "github.com/opencontainers/go-digest"
+)
+
+// ManifestAdd adds images to the manifest list or image index named name,
+// creating it first if it does not already exist in local storage.
+func (ir *ImageEngine) ManifestAdd(ctx context.Context, name string, options entities.ManifestAddOptions) (string, error) {
+	runtime := ir.Libpod.LibimageRuntime()
+	list, err := runtime.LookupManifestList(name)
+	if err != nil {
+		list, err = runtime.CreateManifestList(name)
+		if err != nil {
+			return "", fmt.Errorf("creating manifest list %q: %w", name, err)
+		}
+	}
+
+	for _, image := range options.Images {
+		manifestDigest, manifestSize, manifestType, err := runtime.ResolveManifestInstance(ctx, nil, image)
+		if err != nil {
+			return "", fmt.Errorf("resolving %q for addition to %q: %w", image, name, err)
+		}
+		addOptions := libimage.ManifestAddOptions{
+			All:              options.All,
+			Annotation:       options.Annotation,
+			Arch:             options.Arch,
+			Features:         options.Features,
+			IndexAnnotations: options.IndexAnnotations,
+			OS:               options.OS,
+			OSFeatures:       options.OSFeatures,
+			OSVersion:        options.OSVersion,
+			Variant:          options.Variant,
+		}
+		if err := list.Add(manifestDigest, manifestSize, manifestType, addOptions); err != nil {
+			return "", fmt.Errorf("adding %q to %q: %w", image, name, err)
+		}
+	}
+
+	return list.ID(), nil
+}
+
+// ManifestAnnotate updates the per-platform fields and annotations of the
+// instance identified by instanceDigest within the manifest list named name.
+func (ir *ImageEngine) ManifestAnnotate(ctx context.Context, name, instanceDigest string, options entities.ManifestAnnotateOptions) (string, error) {
+	runtime := ir.Libpod.LibimageRuntime()
+	list, err := runtime.LookupManifestList(name)
+	if err != nil {
+		return "", fmt.Errorf("looking up manifest list %q: %w", name, err)
+	}
+
+	instanceDig, err := digest.Parse(instanceDigest)
+	if err != nil {
+		return "", fmt.Errorf("parsing instance digest %q: %w", instanceDigest, err)
+	}
+
+	annotateOptions := libimage.ManifestAnnotateOptions{
+		Annotation:       options.Annotation,
+		Arch:             options.Arch,
+		Features:         options.Features,
+		IndexAnnotations: options.IndexAnnotations,
+		OS:               options.OS,
+		OSFeatures:       options.OSFeatures,
+		OSVersion:        options.OSVersion,
+		Variant:          options.Variant,
+	}
+	if err := list.AnnotateInstance(instanceDig, annotateOptions); err != nil {
+		return "", fmt.Errorf("annotating %q in %q: %w", instanceDigest, name, err)
+	}
+
+	return list.ID(), nil
+}
+
+// ManifestPush pushes the manifest list or image index named name to every
+// destination in options.Destinations, rolling back the ones that succeeded
+// if any of them fails, then optionally removes the local list on success.
+func (ir *ImageEngine) ManifestPush(ctx context.Context, name string, options entities.ManifestPushOptions) (string, error) {
+	runtime := ir.Libpod.LibimageRuntime()
+	list, err := runtime.LookupManifestList(name)
+	if err != nil {
+		return "", fmt.Errorf("looking up manifest list %q: %w", name, err)
+	}
+
+	pushOptions := libimage.ManifestPushOptions{
+		Destinations:                 options.Destinations,
+		Quiet:                        options.Quiet,
+		Authfile:                     options.Authfile,
+		CertDir:                      options.CertDir,
+		Creds:                        options.Creds,
+		TlsVerify:                    options.TlsVerify,
+		DigestFile:                   options.DigestFile,
+		Format:                       options.Format,
+		RemoveSignatures:             options.RemoveSignatures,
+		SignBy:                       options.SignBy,
+		SignBySigstorePrivateKeyFile: options.SignBySigstorePrivateKeyFile,
+		SignBySigstorePassphraseFile: options.SignBySigstorePassphraseFile,
+		SignaturePolicy:              options.SignaturePolicy,
+	}
+	if _, err := list.Push(ctx, pushOptions); err != nil {
+		return "", err
+	}
+
+	id := list.ID()
+	if options.Purge {
+		if err := runtime.RemoveManifestList(name); err != nil {
+			return "", fmt.Errorf("removing %q after push: %w", name, err)
+		}
+	}
+
+	return id, nil
+}